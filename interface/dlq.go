@@ -0,0 +1,40 @@
+package _interface
+
+import "time"
+
+// DLQPolicy configures dead-letter and retry-topic behavior for a
+// subscription, modeled on Pulsar's dead letter topic and retry letter
+// topic pattern. When a message's DeliveryCount exceeds MaxDeliveries,
+// the broker republishes it to DeadLetterTopic instead of redelivering
+// it on the original subscription.
+type DLQPolicy struct {
+	// MaxDeliveries is the maximum number of times a message may be
+	// delivered, including redeliveries caused by ReconsumeLater, before
+	// it is routed to DeadLetterTopic instead of being redelivered.
+	MaxDeliveries int
+
+	// DeadLetterTopic is the topic a message is republished to once
+	// MaxDeliveries has been exceeded.
+	DeadLetterTopic string
+
+	// RetryTopic is the topic a message is republished to when a
+	// consumer calls ReconsumeLater instead of acking or nacking it.
+	RetryTopic string
+}
+
+// RetryingSubscriber extends Subscriber with the ability to postpone
+// redelivery of a message instead of acking or nacking it immediately.
+// Implementations are expected to track DeliveryCount and enforce the
+// subscription's DLQPolicy on the caller's behalf.
+type RetryingSubscriber interface {
+	Subscriber
+
+	// ReconsumeLater stamps msg with OriginMessageID, increments
+	// ReconsumeTimes, and republishes it to the subscription's
+	// RetryTopic with DelayTime set to the current time plus delay. A
+	// RetryScheduler withholds redelivery of msg until DelayTime has
+	// elapsed. If msg.DeliveryCount has already exceeded the
+	// subscription's DLQPolicy.MaxDeliveries, msg is republished to
+	// DeadLetterTopic instead and the delay is ignored.
+	ReconsumeLater(msg *Message, delay time.Duration) error
+}
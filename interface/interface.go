@@ -24,6 +24,45 @@ type Message struct {
 	// messages, e.g., in a request-response pattern, or to group messages
 	// belonging to the same processing unit.
 	CorrelationID string
+
+	// DeliveryCount is the number of times this message has been
+	// delivered to a subscriber, including the current delivery. The
+	// broker increments it on every redelivery and uses it, together
+	// with the subscription's DLQPolicy, to decide when a message has
+	// exhausted its retries and must be routed to the dead letter topic.
+	DeliveryCount int
+
+	// OriginMessageID is the MessageID of the message as it was first
+	// published, before any dead-letter or retry-topic republishing.
+	// It is stamped the first time a message is republished to a retry
+	// or dead letter topic, so the original message can still be traced
+	// once DeliveryCount resets on the new topic.
+	OriginMessageID string
+
+	// ReconsumeTimes counts how many times this message has been
+	// republished to a retry topic via ReconsumeLater. It mirrors
+	// Pulsar's RECONSUME_TIMES property.
+	ReconsumeTimes int
+
+	// DelayTime is the Unix time in milliseconds before which a message
+	// on a retry topic must not be redelivered. The broker's retry
+	// scheduler withholds delivery until this time has elapsed.
+	DelayTime int64
+
+	// OrderingKey, when non-nil, identifies the ordering stream this
+	// message belongs to. On a subscription with MessageOrderingEnabled,
+	// messages within a partition that share an OrderingKey are
+	// delivered strictly one at a time: the broker
+	// withholds the next message for a key until the previous one is
+	// acked or its ack-timeout fires.
+	OrderingKey []byte
+
+	// PartitionKey, when non-nil, is the key Publisher.PublishAsync
+	// passes to Partitioner.Partition to choose this message's
+	// partition. It is a distinct concern from OrderingKey: two
+	// messages can share a PartitionKey to land on the same partition
+	// without being part of the same ordered stream, and vice versa.
+	PartitionKey []byte
 }
 
 // AckMessage represents a message acknowledgement.
@@ -79,6 +118,24 @@ type SubscriptionOptions struct {
 	// ensures that the system does not get stuck in an infinite retry loop for a
 	// single failed message.
 	MaxRetries int
+
+	// DLQPolicy configures dead-letter and retry-topic handling for this
+	// subscription. A nil DLQPolicy disables dead-lettering; messages
+	// are redelivered according to DeliveryGuarantee and MaxRetries
+	// alone.
+	DLQPolicy *DLQPolicy
+
+	// SubscribeSettings configures outstanding-message flow control for
+	// a streaming subscription created via Subscriber.SubscribeStream.
+	// A nil value means DefaultSubscribeSettings is used.
+	SubscribeSettings *SubscribeSettings
+
+	// MessageOrderingEnabled, when true, requires the broker to dispatch
+	// messages sharing the same OrderingKey strictly one at a time
+	// within a partition. Different keys may still be dispatched in
+	// parallel. It has no effect on messages published without an
+	// OrderingKey.
+	MessageOrderingEnabled bool
 }
 
 // Subscriber is the interface for a Pub/Sub subscriber.
@@ -91,6 +148,13 @@ type Subscriber interface {
 	// such as message size limit, ack timeout, or delivery guarantee.
 	// The ackChan is a channel used for sending acknowledgements for processed messages.
 	Subscribe(topic string, handler func([]*Message), metadata interface{}, options *SubscriptionOptions, ackChan chan<- *AckMessage) error
+	// SubscribeStream opens a flow-controlled Stream for the specified
+	// topic. Unlike Subscribe, delivery is bounded by a token budget:
+	// options.SubscribeSettings seeds the initial budget, and once it
+	// is used up no further messages are delivered until the caller
+	// grants capacity via Stream.AllowFlow, which protects slow
+	// consumers from being overwhelmed.
+	SubscribeStream(topic string, options *SubscriptionOptions) (Stream, error)
 	// Unsubscribe removes the handler for the specified topic. This stops the
 	// subscriber from receiving messages for that topic.
 	Unsubscribe(topic string) error
@@ -107,6 +171,13 @@ type Publisher interface {
 	// The metadata parameter is used to provide additional information about the
 	// messages, such as the message ID or timestamp.
 	Publish(topic string, key []byte, messages []*Message, metadata interface{}) error
+
+	// PublishAsync enqueues msg for publishing and returns immediately.
+	// Messages are grouped by partition and flushed in batches
+	// according to the Publisher's PublisherSettings; call Get on the
+	// returned PublishResult to wait for msg to actually be flushed.
+	PublishAsync(topic string, msg *Message) PublishResult
+
 	// Close closes the publisher and releases any resources. It should be called
 	// when the publisher is no longer needed to ensure proper cleanup.
 	Close() error
@@ -140,6 +211,16 @@ type Partition interface {
 	// determine the order in which messages are delivered to the subscribers.
 	SetOrderingKey(orderingKey []byte) error
 
+	// Seek repositions this partition's read cursor to target. It is
+	// the primary building block for replay and consumer-group-style
+	// recovery; see SeekTarget for the supported variants.
+	Seek(target SeekTarget) error
+
+	// Committer returns the Committer that persists read-cursor commits
+	// for subscriberID on this partition, so Subscribe can resume from
+	// the last committed offset instead of always tailing the log.
+	Committer(subscriberID string) Committer
+
 	// Close closes the partition and releases any resources. It should be called
 	// when the partition is no longer needed to ensure proper cleanup.
 	Close() error
@@ -152,6 +233,16 @@ type PubSub interface {
 	// ensuring better scalability and fault tolerance.
 	Partitioner() Partitioner
 
+	// PartitionCount returns the number of partitions this Pub/Sub
+	// system was configured with.
+	PartitionCount() int
+
+	// PartitionForKey returns the partition index that key would route
+	// to for topic, without publishing or resolving the underlying
+	// Partition. It lets publishers inspect routing decisions for
+	// observability and testing.
+	PartitionForKey(topic string, key []byte) int
+
 	// Close closes the Pub/Sub system and releases any resources. It should be called
 	// when the Pub/Sub system is no longer needed to ensure proper cleanup.
 	Close() error
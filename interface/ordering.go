@@ -0,0 +1,130 @@
+package _interface
+
+import "sync"
+
+// OrderedSubscriber extends Subscriber with the ability to recover an
+// ordering key after a nack has poisoned its delivery queue. When
+// MessageOrderingEnabled is set and a message nacks (or its ack-timeout
+// fires) after exhausting MaxRetries, the broker stops dispatching
+// further messages for that OrderingKey until ResumeOrdering is called,
+// matching Cloud Pub/Sub's ordering-resume semantics.
+type OrderedSubscriber interface {
+	Subscriber
+
+	// ResumeOrdering resumes dispatch of messages for key after it was
+	// paused due to a poisoned delivery. It is a no-op if key is not
+	// currently paused.
+	ResumeOrdering(key []byte) error
+}
+
+// PerKeyDispatcher serializes delivery of messages that share an
+// OrderingKey, while letting different keys dispatch concurrently: it
+// is the building block an OrderedSubscriber implementation uses to
+// honor SubscriptionOptions.MessageOrderingEnabled. A key only ever has
+// one message in flight; Dispatch queues any further messages for that
+// key until Ack (or Nack, followed by ResumeOrdering) releases the next
+// one.
+type PerKeyDispatcher struct {
+	deliver func(msg *Message)
+
+	mu     sync.Mutex
+	busy   map[string]bool
+	paused map[string]bool
+	queues map[string][]*Message
+}
+
+// NewPerKeyDispatcher creates a PerKeyDispatcher that calls deliver
+// exactly once for each message it releases, in per-key order.
+func NewPerKeyDispatcher(deliver func(msg *Message)) *PerKeyDispatcher {
+	return &PerKeyDispatcher{
+		deliver: deliver,
+		busy:    make(map[string]bool),
+		paused:  make(map[string]bool),
+		queues:  make(map[string][]*Message),
+	}
+}
+
+// Dispatch delivers msg immediately if its OrderingKey is idle, or
+// queues it behind the key's in-flight message otherwise. Messages
+// with a nil OrderingKey bypass serialization entirely and are always
+// delivered immediately, matching MessageOrderingEnabled's documented
+// no-op behavior for keyless messages.
+func (d *PerKeyDispatcher) Dispatch(msg *Message) {
+	if msg.OrderingKey == nil {
+		d.deliver(msg)
+		return
+	}
+	key := string(msg.OrderingKey)
+
+	d.mu.Lock()
+	if d.busy[key] || d.paused[key] {
+		d.queues[key] = append(d.queues[key], msg)
+		d.mu.Unlock()
+		return
+	}
+	d.busy[key] = true
+	d.mu.Unlock()
+
+	d.deliver(msg)
+}
+
+// Ack marks the in-flight message for key as delivered and releases the
+// next queued message for that key, if any.
+func (d *PerKeyDispatcher) Ack(key []byte) {
+	d.mu.Lock()
+	next := d.releaseLocked(string(key))
+	d.mu.Unlock()
+
+	if next != nil {
+		d.deliver(next)
+	}
+}
+
+// Nack marks key as poisoned: no further queued messages for key are
+// dispatched until ResumeOrdering is called.
+func (d *PerKeyDispatcher) Nack(key []byte) {
+	k := string(key)
+
+	d.mu.Lock()
+	d.busy[k] = false
+	d.paused[k] = true
+	d.mu.Unlock()
+}
+
+// ResumeOrdering clears the poisoned state for key, if any, and resumes
+// dispatch of any messages queued for it.
+func (d *PerKeyDispatcher) ResumeOrdering(key []byte) error {
+	k := string(key)
+
+	d.mu.Lock()
+	if !d.paused[k] {
+		d.mu.Unlock()
+		return nil
+	}
+	delete(d.paused, k)
+	next := d.releaseLocked(k)
+	d.mu.Unlock()
+
+	if next != nil {
+		d.deliver(next)
+	}
+	return nil
+}
+
+// releaseLocked pops and marks in-flight the next queued message for k,
+// if k is neither busy nor paused. d.mu must be held by the caller; the
+// returned message, if any, must be delivered after the caller unlocks.
+func (d *PerKeyDispatcher) releaseLocked(k string) *Message {
+	d.busy[k] = false
+	if d.paused[k] {
+		return nil
+	}
+	queue := d.queues[k]
+	if len(queue) == 0 {
+		return nil
+	}
+	next := queue[0]
+	d.queues[k] = queue[1:]
+	d.busy[k] = true
+	return next
+}
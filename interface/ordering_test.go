@@ -0,0 +1,95 @@
+package _interface
+
+import "testing"
+
+func TestPerKeyDispatcherSerializesSameKey(t *testing.T) {
+	delivered := make(chan *Message, 10)
+	d := NewPerKeyDispatcher(func(msg *Message) { delivered <- msg })
+
+	key := []byte("k1")
+	d.Dispatch(&Message{MessageID: "1", OrderingKey: key})
+	d.Dispatch(&Message{MessageID: "2", OrderingKey: key})
+	d.Dispatch(&Message{MessageID: "3", OrderingKey: key})
+
+	first := <-delivered
+	if first.MessageID != "1" {
+		t.Fatalf("first delivered = %s, want 1", first.MessageID)
+	}
+	select {
+	case <-delivered:
+		t.Fatal("second message delivered before the first was acked")
+	default:
+	}
+
+	d.Ack(key)
+	if second := <-delivered; second.MessageID != "2" {
+		t.Fatalf("second delivered = %s, want 2", second.MessageID)
+	}
+
+	d.Ack(key)
+	if third := <-delivered; third.MessageID != "3" {
+		t.Fatalf("third delivered = %s, want 3", third.MessageID)
+	}
+}
+
+func TestPerKeyDispatcherDifferentKeysDispatchIndependently(t *testing.T) {
+	delivered := make(chan *Message, 10)
+	d := NewPerKeyDispatcher(func(msg *Message) { delivered <- msg })
+
+	d.Dispatch(&Message{MessageID: "a", OrderingKey: []byte("key-a")})
+	d.Dispatch(&Message{MessageID: "b", OrderingKey: []byte("key-b")})
+
+	seen := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		seen[(<-delivered).MessageID] = true
+	}
+	if !seen["a"] || !seen["b"] {
+		t.Fatalf("expected key-a and key-b messages delivered without waiting on each other, got %v", seen)
+	}
+}
+
+func TestPerKeyDispatcherNilOrderingKeyBypassesSerialization(t *testing.T) {
+	delivered := make(chan *Message, 10)
+	d := NewPerKeyDispatcher(func(msg *Message) { delivered <- msg })
+
+	d.Dispatch(&Message{MessageID: "1"})
+	d.Dispatch(&Message{MessageID: "2"})
+
+	seen := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case msg := <-delivered:
+			seen[msg.MessageID] = true
+		default:
+			t.Fatal("expected both keyless messages to be delivered immediately, without any Ack")
+		}
+	}
+	if !seen["1"] || !seen["2"] {
+		t.Fatalf("expected both 1 and 2 delivered, got %v", seen)
+	}
+}
+
+func TestPerKeyDispatcherNackThenResumeOrdering(t *testing.T) {
+	delivered := make(chan *Message, 10)
+	d := NewPerKeyDispatcher(func(msg *Message) { delivered <- msg })
+
+	key := []byte("poison")
+	d.Dispatch(&Message{MessageID: "1", OrderingKey: key})
+	<-delivered
+
+	d.Dispatch(&Message{MessageID: "2", OrderingKey: key})
+	d.Nack(key)
+
+	select {
+	case <-delivered:
+		t.Fatal("queued message delivered while its key is paused")
+	default:
+	}
+
+	if err := d.ResumeOrdering(key); err != nil {
+		t.Fatalf("ResumeOrdering: %v", err)
+	}
+	if msg := <-delivered; msg.MessageID != "2" {
+		t.Fatalf("delivered = %s, want 2", msg.MessageID)
+	}
+}
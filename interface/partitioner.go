@@ -0,0 +1,126 @@
+package _interface
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+)
+
+// KeyIndexer is implemented by Partitioners that can report which
+// partition index a key routes to without resolving the underlying
+// Partition. PubSub.PartitionForKey uses it when the configured
+// Partitioner supports it.
+type KeyIndexer interface {
+	// IndexForKey returns the partition index, in [0, numPartitions),
+	// that key routes to.
+	IndexForKey(key []byte) int
+}
+
+// HashPartitioner routes a publish key to a partition by hashing it with
+// SHA-256 and reducing the result modulo the partition count, so that
+// messages sharing a key always land on the same partition, even across
+// broker restarts. Messages published with a nil key fall back to
+// round-robin assignment across the available partitions.
+type HashPartitioner struct {
+	numPartitions int
+	resolve       func(topic string, index int) (Partition, error)
+	next          uint64
+}
+
+// NewHashPartitioner creates a HashPartitioner over numPartitions
+// partitions. resolve is called with the chosen index to obtain (or
+// lazily create) the Partition for a topic. It returns an error if
+// numPartitions is not positive.
+func NewHashPartitioner(numPartitions int, resolve func(topic string, index int) (Partition, error)) (*HashPartitioner, error) {
+	if numPartitions <= 0 {
+		return nil, fmt.Errorf("_interface: numPartitions must be positive, got %d", numPartitions)
+	}
+	return &HashPartitioner{numPartitions: numPartitions, resolve: resolve}, nil
+}
+
+// Partition implements Partitioner.
+func (p *HashPartitioner) Partition(topic string, key []byte) (Partition, error) {
+	return p.resolve(topic, p.IndexForKey(key))
+}
+
+// IndexForKey implements KeyIndexer.
+func (p *HashPartitioner) IndexForKey(key []byte) int {
+	if key == nil {
+		return int(atomic.AddUint64(&p.next, 1)-1) % p.numPartitions
+	}
+	sum := sha256.Sum256(key)
+	n := new(big.Int).SetBytes(sum[:])
+	n.Mod(n, big.NewInt(int64(p.numPartitions)))
+	return int(n.Int64())
+}
+
+// RoundRobinPartitioner assigns partitions in round-robin order,
+// ignoring the publish key entirely.
+type RoundRobinPartitioner struct {
+	numPartitions int
+	resolve       func(topic string, index int) (Partition, error)
+	next          uint64
+}
+
+// NewRoundRobinPartitioner creates a RoundRobinPartitioner over
+// numPartitions partitions. resolve is called with the chosen index to
+// obtain (or lazily create) the Partition for a topic. It returns an
+// error if numPartitions is not positive.
+func NewRoundRobinPartitioner(numPartitions int, resolve func(topic string, index int) (Partition, error)) (*RoundRobinPartitioner, error) {
+	if numPartitions <= 0 {
+		return nil, fmt.Errorf("_interface: numPartitions must be positive, got %d", numPartitions)
+	}
+	return &RoundRobinPartitioner{numPartitions: numPartitions, resolve: resolve}, nil
+}
+
+// Partition implements Partitioner.
+func (p *RoundRobinPartitioner) Partition(topic string, key []byte) (Partition, error) {
+	return p.resolve(topic, p.IndexForKey(key))
+}
+
+// IndexForKey implements KeyIndexer.
+func (p *RoundRobinPartitioner) IndexForKey(key []byte) int {
+	return int(atomic.AddUint64(&p.next, 1)-1) % p.numPartitions
+}
+
+// pubSub is the default PubSub implementation returned by NewPubSub.
+type pubSub struct {
+	numPartitions int
+	partitioner   Partitioner
+}
+
+// NewPubSub creates a PubSub configured with numPartitions partitions,
+// routed through p. It returns an error if numPartitions is not
+// positive.
+func NewPubSub(numPartitions int, p Partitioner) (PubSub, error) {
+	if numPartitions <= 0 {
+		return nil, fmt.Errorf("_interface: numPartitions must be positive, got %d", numPartitions)
+	}
+	return &pubSub{numPartitions: numPartitions, partitioner: p}, nil
+}
+
+func (ps *pubSub) Partitioner() Partitioner {
+	return ps.partitioner
+}
+
+func (ps *pubSub) PartitionCount() int {
+	return ps.numPartitions
+}
+
+// PartitionForKey implements PubSub by asking the Partitioner's
+// KeyIndexer. It returns 0 if the Partitioner does not implement
+// KeyIndexer. Where the Partitioner assigns round-robin (any key for
+// RoundRobinPartitioner, a nil key for HashPartitioner), the call
+// takes a round-robin turn, so the next publish routes to the index
+// after the one returned.
+func (ps *pubSub) PartitionForKey(topic string, key []byte) int {
+	if ki, ok := ps.partitioner.(KeyIndexer); ok {
+		return ki.IndexForKey(key)
+	}
+	return 0
+}
+
+func (ps *pubSub) Close() error {
+	return nil
+}
@@ -0,0 +1,93 @@
+package _interface
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestHashPartitionerIndexForKeyIsSticky(t *testing.T) {
+	p, err := NewHashPartitioner(8, nil)
+	if err != nil {
+		t.Fatalf("NewHashPartitioner: %v", err)
+	}
+
+	key := []byte("order-42")
+	first := p.IndexForKey(key)
+	for i := 0; i < 100; i++ {
+		if got := p.IndexForKey(key); got != first {
+			t.Fatalf("IndexForKey(%q) = %d, want %d (stickiness)", key, got, first)
+		}
+	}
+}
+
+func TestHashPartitionerIndexForKeyDistribution(t *testing.T) {
+	p, err := NewHashPartitioner(4, nil)
+	if err != nil {
+		t.Fatalf("NewHashPartitioner: %v", err)
+	}
+
+	seen := make(map[int]bool)
+	for i := 0; i < 1000; i++ {
+		key := []byte(fmt.Sprintf("key-%d", i))
+		idx := p.IndexForKey(key)
+		if idx < 0 || idx >= 4 {
+			t.Fatalf("IndexForKey(%q) = %d, want index in [0, 4)", key, idx)
+		}
+		seen[idx] = true
+	}
+	if len(seen) != 4 {
+		t.Fatalf("1000 distinct keys spread across %d partitions, want all 4", len(seen))
+	}
+}
+
+func TestHashPartitionerNilKeyFallsBackToRoundRobin(t *testing.T) {
+	p, err := NewHashPartitioner(3, nil)
+	if err != nil {
+		t.Fatalf("NewHashPartitioner: %v", err)
+	}
+
+	for i := 0; i < 6; i++ {
+		want := i % 3
+		if got := p.IndexForKey(nil); got != want {
+			t.Fatalf("IndexForKey(nil) call %d = %d, want %d", i, got, want)
+		}
+	}
+}
+
+func TestRoundRobinPartitionerCyclesThroughPartitions(t *testing.T) {
+	p, err := NewRoundRobinPartitioner(3, nil)
+	if err != nil {
+		t.Fatalf("NewRoundRobinPartitioner: %v", err)
+	}
+
+	for i := 0; i < 9; i++ {
+		want := i % 3
+		if got := p.IndexForKey([]byte("ignored")); got != want {
+			t.Fatalf("IndexForKey call %d = %d, want %d", i, got, want)
+		}
+	}
+}
+
+func TestNewHashPartitionerRejectsNonPositivePartitions(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		if _, err := NewHashPartitioner(n, nil); err == nil {
+			t.Fatalf("NewHashPartitioner(%d, nil) returned no error", n)
+		}
+	}
+}
+
+func TestNewRoundRobinPartitionerRejectsNonPositivePartitions(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		if _, err := NewRoundRobinPartitioner(n, nil); err == nil {
+			t.Fatalf("NewRoundRobinPartitioner(%d, nil) returned no error", n)
+		}
+	}
+}
+
+func TestNewPubSubRejectsNonPositivePartitions(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		if _, err := NewPubSub(n, nil); err == nil {
+			t.Fatalf("NewPubSub(%d, nil) returned no error", n)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package _interface
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// PublishResult is returned by Publisher.PublishAsync and resolves once
+// the message it represents has been flushed to its partition.
+type PublishResult interface {
+	// Get blocks until the message is flushed, returning the MessageID
+	// the message carried when it was passed to PublishAsync, or the
+	// error that prevented publishing.
+	Get(ctx context.Context) (messageID string, err error)
+}
+
+// PublisherSettings configures the batching behavior of a Publisher
+// created with NewBatchingPublisher. A batch is flushed as soon as any
+// one of MaxBatchBytes, MaxBatchMessages, or MaxBatchDelay is reached,
+// mirroring the batching model used by Pub/Sub, Pub/Sub Lite, and
+// Pulsar clients. A flushed batch is handed to its Partition one
+// message at a time, through Partition.Publish.
+type PublisherSettings struct {
+	// MaxBatchBytes is the maximum total size, in bytes, of a batch's
+	// message values before it is flushed.
+	MaxBatchBytes int
+
+	// MaxBatchMessages is the maximum number of messages in a batch
+	// before it is flushed.
+	MaxBatchMessages int
+
+	// MaxBatchDelay is the maximum time a message waits in a batch
+	// before the batch is flushed, regardless of its size.
+	MaxBatchDelay time.Duration
+}
+
+// DefaultPublisherSettings are reasonable batching defaults for a new
+// Publisher.
+var DefaultPublisherSettings = PublisherSettings{
+	MaxBatchBytes:    1000 * 1000,
+	MaxBatchMessages: 100,
+	MaxBatchDelay:    10 * time.Millisecond,
+}
+
+// publishResult is the default PublishResult implementation.
+type publishResult struct {
+	done      chan struct{}
+	messageID string
+	err       error
+}
+
+func newPublishResult() *publishResult {
+	return &publishResult{done: make(chan struct{})}
+}
+
+func (r *publishResult) resolve(messageID string, err error) {
+	r.messageID, r.err = messageID, err
+	close(r.done)
+}
+
+// Get implements PublishResult.
+func (r *publishResult) Get(ctx context.Context) (string, error) {
+	select {
+	case <-r.done:
+		return r.messageID, r.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// publishBatch accumulates messages for a single partition until one of
+// the PublisherSettings thresholds trips.
+type publishBatch struct {
+	messages []*Message
+	results  []*publishResult
+	bytes    int
+	timer    *time.Timer
+}
+
+// batchingPublisher is the Publisher implementation returned by
+// NewBatchingPublisher. It groups PublishAsync calls by partition and
+// flushes each group once MaxBatchBytes, MaxBatchMessages, or
+// MaxBatchDelay is reached.
+type batchingPublisher struct {
+	partitioner Partitioner
+	settings    PublisherSettings
+
+	mu      sync.Mutex
+	batches map[Partition]*publishBatch
+}
+
+// NewBatchingPublisher creates a Publisher that routes messages through
+// p and batches them per partition according to settings.
+func NewBatchingPublisher(p Partitioner, settings PublisherSettings) Publisher {
+	return &batchingPublisher{
+		partitioner: p,
+		settings:    settings,
+		batches:     make(map[Partition]*publishBatch),
+	}
+}
+
+// PublishAsync implements Publisher. The message's partition is chosen
+// from its PartitionKey, which is a distinct concern from OrderingKey:
+// PartitionKey only steers routing, while OrderingKey (passed through
+// to Partition.Publish) steers in-partition delivery order.
+func (bp *batchingPublisher) PublishAsync(topic string, msg *Message) PublishResult {
+	result := newPublishResult()
+
+	partition, err := bp.partitioner.Partition(topic, msg.PartitionKey)
+	if err != nil {
+		result.resolve("", err)
+		return result
+	}
+
+	bp.mu.Lock()
+	batch, ok := bp.batches[partition]
+	if !ok {
+		batch = &publishBatch{}
+		bp.batches[partition] = batch
+		batch.timer = time.AfterFunc(bp.settings.MaxBatchDelay, func() { bp.flush(partition) })
+	}
+	batch.messages = append(batch.messages, msg)
+	batch.results = append(batch.results, result)
+	batch.bytes += len(msg.Value)
+
+	tripped := len(batch.messages) >= bp.settings.MaxBatchMessages || batch.bytes >= bp.settings.MaxBatchBytes
+	bp.mu.Unlock()
+
+	if tripped {
+		bp.flush(partition)
+	}
+	return result
+}
+
+// flush publishes and retires the pending batch for partition, if any.
+func (bp *batchingPublisher) flush(partition Partition) {
+	bp.mu.Lock()
+	batch, ok := bp.batches[partition]
+	if ok {
+		delete(bp.batches, partition)
+	}
+	bp.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	batch.timer.Stop()
+	for i, msg := range batch.messages {
+		err := partition.Publish(msg.Value, nil, msg.OrderingKey)
+		batch.results[i].resolve(msg.MessageID, err)
+	}
+}
+
+// Publish implements Publisher as a thin wrapper around PublishAsync
+// that awaits every message's result before returning. key only steers
+// partition routing, via PartitionKey, for messages that don't already
+// carry one of their own; it never touches OrderingKey, and callers'
+// Message values are never modified.
+func (bp *batchingPublisher) Publish(topic string, key []byte, messages []*Message, metadata interface{}) error {
+	results := make([]PublishResult, len(messages))
+	for i, msg := range messages {
+		routed := msg
+		if msg.PartitionKey == nil && key != nil {
+			copied := *msg
+			copied.PartitionKey = key
+			routed = &copied
+		}
+		results[i] = bp.PublishAsync(topic, routed)
+	}
+	for _, result := range results {
+		if _, err := result.Get(context.Background()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close implements Publisher by flushing any pending batches.
+func (bp *batchingPublisher) Close() error {
+	bp.mu.Lock()
+	pending := make([]Partition, 0, len(bp.batches))
+	for partition := range bp.batches {
+		pending = append(pending, partition)
+	}
+	bp.mu.Unlock()
+
+	for _, partition := range pending {
+		bp.flush(partition)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package _interface
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakePartition records every value published to it, standing in for a
+// real log-backed Partition in tests.
+type fakePartition struct {
+	mu        sync.Mutex
+	published [][]byte
+}
+
+func (p *fakePartition) Subscribe(func([]*Message), interface{}, *SubscriptionOptions, chan<- *AckMessage) error {
+	return nil
+}
+func (p *fakePartition) Unsubscribe() error { return nil }
+func (p *fakePartition) Publish(value []byte, metadata interface{}, orderingKey []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.published = append(p.published, value)
+	return nil
+}
+func (p *fakePartition) SetOrderingKey([]byte) error { return nil }
+func (p *fakePartition) Seek(SeekTarget) error       { return nil }
+func (p *fakePartition) Committer(string) Committer  { return nil }
+func (p *fakePartition) Close() error                { return nil }
+
+func (p *fakePartition) publishedCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.published)
+}
+
+// fakePartitioner always routes to a single fakePartition, which is all
+// these tests need since they exercise batching, not routing.
+type fakePartitioner struct {
+	partition *fakePartition
+}
+
+func (fp *fakePartitioner) Partition(topic string, key []byte) (Partition, error) {
+	return fp.partition, nil
+}
+
+func TestBatchingPublisherFlushesOnMessageCountThreshold(t *testing.T) {
+	partition := &fakePartition{}
+	pub := NewBatchingPublisher(&fakePartitioner{partition: partition}, PublisherSettings{
+		MaxBatchBytes:    1 << 20,
+		MaxBatchMessages: 3,
+		MaxBatchDelay:    time.Hour, // long enough that only the count threshold can trip
+	})
+
+	const n = 3
+	results := make([]PublishResult, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = pub.PublishAsync("orders", &Message{Value: []byte("m")})
+		}()
+	}
+	wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for i, r := range results {
+		if _, err := r.Get(ctx); err != nil {
+			t.Fatalf("result %d Get: %v", i, err)
+		}
+	}
+
+	if got := partition.publishedCount(); got != n {
+		t.Fatalf("partition received %d messages, want %d", got, n)
+	}
+}
+
+func TestBatchingPublisherPublishDoesNotMutateCallerMessage(t *testing.T) {
+	partition := &fakePartition{}
+	pub := NewBatchingPublisher(&fakePartitioner{partition: partition}, DefaultPublisherSettings)
+
+	msg := &Message{Value: []byte("m")}
+	key := []byte("routing-key")
+	if err := pub.Publish("orders", key, []*Message{msg}, nil); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if msg.PartitionKey != nil {
+		t.Fatalf("Publish mutated caller's message: PartitionKey = %q, want nil", msg.PartitionKey)
+	}
+	if msg.OrderingKey != nil {
+		t.Fatalf("Publish set OrderingKey from the routing key: OrderingKey = %q, want nil", msg.OrderingKey)
+	}
+}
+
+func TestBatchingPublisherRoutesOnPartitionKeyNotOrderingKey(t *testing.T) {
+	partitionA := &fakePartition{}
+	partitionB := &fakePartition{}
+	partitioner := &keyedFakePartitioner{
+		byKey: map[string]*fakePartition{
+			"a": partitionA,
+			"b": partitionB,
+		},
+	}
+	pub := NewBatchingPublisher(partitioner, DefaultPublisherSettings)
+
+	// Same OrderingKey, different PartitionKey: these must not be forced
+	// onto the same partition just because they share an ordering key.
+	msg1 := &Message{Value: []byte("1"), OrderingKey: []byte("same-order"), PartitionKey: []byte("a")}
+	msg2 := &Message{Value: []byte("2"), OrderingKey: []byte("same-order"), PartitionKey: []byte("b")}
+
+	ctx := context.Background()
+	if _, err := pub.PublishAsync("orders", msg1).Get(ctx); err != nil {
+		t.Fatalf("PublishAsync(msg1): %v", err)
+	}
+	if _, err := pub.PublishAsync("orders", msg2).Get(ctx); err != nil {
+		t.Fatalf("PublishAsync(msg2): %v", err)
+	}
+
+	if got := partitionA.publishedCount(); got != 1 {
+		t.Fatalf("partitionA received %d messages, want 1", got)
+	}
+	if got := partitionB.publishedCount(); got != 1 {
+		t.Fatalf("partitionB received %d messages, want 1", got)
+	}
+}
+
+// keyedFakePartitioner routes by a string form of the partition key, so
+// tests can assert which of several partitions a message landed on.
+type keyedFakePartitioner struct {
+	byKey map[string]*fakePartition
+}
+
+func (kp *keyedFakePartitioner) Partition(topic string, key []byte) (Partition, error) {
+	return kp.byKey[string(key)], nil
+}
@@ -0,0 +1,96 @@
+package _interface
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// RetryDelivery is a message a RetryScheduler has released because its
+// DelayTime has elapsed, paired with the retry topic it should be
+// republished to.
+type RetryDelivery struct {
+	Message *Message
+	Topic   string
+}
+
+// RetryScheduler is a minimal in-memory scheduler for a DLQPolicy's
+// RetryTopic: it withholds a message from redelivery until its
+// DelayTime has elapsed, ordering pending messages in a min-heap keyed
+// by DelayTime so Ready only ever pays for the entries that are
+// actually due. It also enforces MaxDeliveries, reporting that a
+// message belongs on the dead letter topic instead of being scheduled.
+type RetryScheduler struct {
+	mu      sync.Mutex
+	entries retryHeap
+}
+
+// NewRetryScheduler creates an empty RetryScheduler.
+func NewRetryScheduler() *RetryScheduler {
+	return &RetryScheduler{}
+}
+
+// Schedule enqueues msg for redelivery on topic once msg.DelayTime has
+// elapsed. If policy is non-nil, policy.MaxDeliveries is positive, and
+// msg.DeliveryCount has already exceeded policy.MaxDeliveries, Schedule
+// leaves the scheduler untouched and returns deadLettered == true so the
+// caller can republish msg to policy.DeadLetterTopic instead. A policy
+// with MaxDeliveries <= 0 never dead-letters.
+func (s *RetryScheduler) Schedule(msg *Message, topic string, policy *DLQPolicy) (deadLettered bool) {
+	if policy != nil && policy.MaxDeliveries > 0 && msg.DeliveryCount > policy.MaxDeliveries {
+		return true
+	}
+
+	s.mu.Lock()
+	heap.Push(&s.entries, &retryEntry{msg: msg, topic: topic})
+	s.mu.Unlock()
+	return false
+}
+
+// Ready pops and returns every scheduled message whose DelayTime is at
+// or before now (Unix milliseconds), in ascending DelayTime order.
+func (s *RetryScheduler) Ready(now int64) []RetryDelivery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var ready []RetryDelivery
+	for len(s.entries) > 0 && s.entries[0].msg.DelayTime <= now {
+		e := heap.Pop(&s.entries).(*retryEntry)
+		ready = append(ready, RetryDelivery{Message: e.msg, Topic: e.topic})
+	}
+	return ready
+}
+
+// Len reports how many messages are still withheld awaiting their
+// DelayTime.
+func (s *RetryScheduler) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+// retryEntry is one message withheld by a RetryScheduler.
+type retryEntry struct {
+	msg   *Message
+	topic string
+}
+
+// retryHeap is a container/heap.Interface ordering retryEntry values by
+// ascending DelayTime.
+type retryHeap []*retryEntry
+
+func (h retryHeap) Len() int           { return len(h) }
+func (h retryHeap) Less(i, j int) bool { return h[i].msg.DelayTime < h[j].msg.DelayTime }
+func (h retryHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *retryHeap) Push(x interface{}) {
+	*h = append(*h, x.(*retryEntry))
+}
+
+func (h *retryHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return item
+}
@@ -0,0 +1,62 @@
+package _interface
+
+import "testing"
+
+func TestRetrySchedulerReadyOrdersByDelayTime(t *testing.T) {
+	s := NewRetryScheduler()
+	s.Schedule(&Message{MessageID: "late", DelayTime: 300}, "retry-topic", nil)
+	s.Schedule(&Message{MessageID: "early", DelayTime: 100}, "retry-topic", nil)
+	s.Schedule(&Message{MessageID: "mid", DelayTime: 200}, "retry-topic", nil)
+
+	ready := s.Ready(1000)
+	if len(ready) != 3 {
+		t.Fatalf("Ready returned %d deliveries, want 3", len(ready))
+	}
+	want := []string{"early", "mid", "late"}
+	for i, w := range want {
+		if ready[i].Message.MessageID != w {
+			t.Fatalf("ready[%d] = %s, want %s", i, ready[i].Message.MessageID, w)
+		}
+		if ready[i].Topic != "retry-topic" {
+			t.Fatalf("ready[%d].Topic = %s, want retry-topic", i, ready[i].Topic)
+		}
+	}
+}
+
+func TestRetrySchedulerWithholdsUntilDelayElapses(t *testing.T) {
+	s := NewRetryScheduler()
+	s.Schedule(&Message{MessageID: "1", DelayTime: 1000}, "retry-topic", nil)
+
+	if ready := s.Ready(500); len(ready) != 0 {
+		t.Fatalf("Ready(500) returned %d deliveries before DelayTime, want 0", len(ready))
+	}
+	if s.Len() != 1 {
+		t.Fatalf("Len() = %d, want 1", s.Len())
+	}
+
+	ready := s.Ready(1000)
+	if len(ready) != 1 || ready[0].Message.MessageID != "1" {
+		t.Fatalf("Ready(1000) = %v, want [message 1]", ready)
+	}
+	if s.Len() != 0 {
+		t.Fatalf("Len() after Ready = %d, want 0", s.Len())
+	}
+}
+
+func TestRetrySchedulerMaxDeliveriesRoutesToDeadLetter(t *testing.T) {
+	s := NewRetryScheduler()
+	policy := &DLQPolicy{MaxDeliveries: 3, DeadLetterTopic: "dlq"}
+
+	atLimit := &Message{MessageID: "1", DeliveryCount: 3, DelayTime: 100}
+	if deadLettered := s.Schedule(atLimit, "retry-topic", policy); deadLettered {
+		t.Fatal("expected a message at DeliveryCount == MaxDeliveries to still be retried")
+	}
+
+	overLimit := &Message{MessageID: "2", DeliveryCount: 4, DelayTime: 100}
+	if deadLettered := s.Schedule(overLimit, "retry-topic", policy); !deadLettered {
+		t.Fatal("expected a message exceeding MaxDeliveries to be reported as dead-lettered")
+	}
+	if s.Len() != 1 {
+		t.Fatalf("Len() = %d, want 1 (dead-lettered message must not be scheduled)", s.Len())
+	}
+}
@@ -0,0 +1,110 @@
+package _interface
+
+import (
+	"sort"
+	"time"
+)
+
+// SeekKind identifies which variant a SeekTarget represents.
+type SeekKind int
+
+const (
+	// SeekKindBeginning targets the oldest retained offset.
+	SeekKindBeginning SeekKind = iota
+	// SeekKindEnd targets the next offset to be written, i.e. it skips
+	// all currently retained messages.
+	SeekKindEnd
+	// SeekKindOffset targets a specific offset.
+	SeekKindOffset
+	// SeekKindPublishTime targets the earliest offset published at or
+	// after a given time.
+	SeekKindPublishTime
+)
+
+// SeekTarget selects where a Partition.Seek call should reposition its
+// read cursor. Construct one with SeekToBeginning, SeekToEnd,
+// SeekToOffset, or SeekToPublishTime rather than building it directly.
+type SeekTarget struct {
+	kind        SeekKind
+	offset      int64
+	publishTime time.Time
+}
+
+// SeekToBeginning targets the oldest retained offset.
+func SeekToBeginning() SeekTarget {
+	return SeekTarget{kind: SeekKindBeginning}
+}
+
+// SeekToEnd targets the next offset to be written.
+func SeekToEnd() SeekTarget {
+	return SeekTarget{kind: SeekKindEnd}
+}
+
+// SeekToOffset targets a specific offset.
+func SeekToOffset(offset int64) SeekTarget {
+	return SeekTarget{kind: SeekKindOffset, offset: offset}
+}
+
+// SeekToPublishTime targets the earliest offset published at or after t.
+func SeekToPublishTime(t time.Time) SeekTarget {
+	return SeekTarget{kind: SeekKindPublishTime, publishTime: t}
+}
+
+// Kind reports which variant target represents.
+func (t SeekTarget) Kind() SeekKind {
+	return t.kind
+}
+
+// Offset returns the target offset. It is only meaningful when
+// Kind() == SeekKindOffset.
+func (t SeekTarget) Offset() int64 {
+	return t.offset
+}
+
+// PublishTime returns the target publish time. It is only meaningful
+// when Kind() == SeekKindPublishTime.
+func (t SeekTarget) PublishTime() time.Time {
+	return t.publishTime
+}
+
+// TimeIndex is a sorted publish-timestamp-to-offset index that a
+// persistent log can use to resolve SeekToPublishTime targets in
+// O(log n) instead of scanning the log. Entries must be appended in
+// non-decreasing timestamp order, matching publish order.
+type TimeIndex struct {
+	timestamps []int64
+	offsets    []int64
+}
+
+// Append records that offset was published at timestamp (Unix
+// milliseconds). timestamp must be >= the timestamp of the previous
+// Append call.
+func (idx *TimeIndex) Append(timestamp, offset int64) {
+	idx.timestamps = append(idx.timestamps, timestamp)
+	idx.offsets = append(idx.offsets, offset)
+}
+
+// OffsetForTime returns the offset of the earliest entry published at
+// or after t, and ok == false if every entry precedes t.
+func (idx *TimeIndex) OffsetForTime(t time.Time) (offset int64, ok bool) {
+	target := t.UnixMilli()
+	i := sort.Search(len(idx.timestamps), func(i int) bool {
+		return idx.timestamps[i] >= target
+	})
+	if i == len(idx.timestamps) {
+		return 0, false
+	}
+	return idx.offsets[i], true
+}
+
+// Committer persists a subscriber's read cursor, keyed by (topic,
+// partition, subscriberID), so Subscribe can resume from the last
+// committed offset instead of always tailing the log.
+type Committer interface {
+	// Commit persists offset as the cursor to resume from.
+	Commit(offset int64) error
+
+	// Committed returns the last committed offset, or ok == false if no
+	// commit has ever been made.
+	Committed() (offset int64, ok bool, err error)
+}
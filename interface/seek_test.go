@@ -0,0 +1,109 @@
+package _interface
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestTimeIndexOffsetForTime(t *testing.T) {
+	idx := &TimeIndex{}
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	idx.Append(base.UnixMilli(), 10)
+	idx.Append(base.Add(1*time.Minute).UnixMilli(), 20)
+	idx.Append(base.Add(2*time.Minute).UnixMilli(), 30)
+
+	tests := []struct {
+		name   string
+		target time.Time
+		offset int64
+		ok     bool
+	}{
+		{"before first entry", base.Add(-time.Second), 10, true},
+		{"exact match on first entry", base, 10, true},
+		{"between first and second entries", base.Add(30 * time.Second), 20, true},
+		{"exact match on last entry", base.Add(2 * time.Minute), 30, true},
+		{"after last entry", base.Add(3 * time.Minute), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset, ok := idx.OffsetForTime(tt.target)
+			if ok != tt.ok {
+				t.Fatalf("OffsetForTime(%v) ok = %v, want %v", tt.target, ok, tt.ok)
+			}
+			if ok && offset != tt.offset {
+				t.Fatalf("OffsetForTime(%v) = %d, want %d", tt.target, offset, tt.offset)
+			}
+		})
+	}
+}
+
+func TestFileCommitterPersistsAcrossInstances(t *testing.T) {
+	dir := t.TempDir()
+
+	c1, err := NewFileCommitter(dir, "orders", 2, "sub-1")
+	if err != nil {
+		t.Fatalf("NewFileCommitter: %v", err)
+	}
+	if _, ok, _ := c1.Committed(); ok {
+		t.Fatal("expected no committed offset before the first Commit")
+	}
+	if err := c1.Commit(42); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	c2, err := NewFileCommitter(dir, "orders", 2, "sub-1")
+	if err != nil {
+		t.Fatalf("NewFileCommitter: %v", err)
+	}
+	offset, ok, err := c2.Committed()
+	if err != nil {
+		t.Fatalf("Committed: %v", err)
+	}
+	if !ok || offset != 42 {
+		t.Fatalf("Committed() = (%d, %v), want (42, true)", offset, ok)
+	}
+}
+
+func TestFileCommitterKeysBySubscriberID(t *testing.T) {
+	dir := t.TempDir()
+
+	subA, err := NewFileCommitter(dir, "orders", 0, "sub-a")
+	if err != nil {
+		t.Fatalf("NewFileCommitter(sub-a): %v", err)
+	}
+	if err := subA.Commit(7); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	subB, err := NewFileCommitter(dir, "orders", 0, "sub-b")
+	if err != nil {
+		t.Fatalf("NewFileCommitter(sub-b): %v", err)
+	}
+	if _, ok, _ := subB.Committed(); ok {
+		t.Fatal("expected sub-b's commit to be independent of sub-a's")
+	}
+}
+
+func TestFileCommitterCommitLeavesNoTempFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	c, err := NewFileCommitter(dir, "orders", 0, "sub-1")
+	if err != nil {
+		t.Fatalf("NewFileCommitter: %v", err)
+	}
+	for offset := int64(1); offset <= 3; offset++ {
+		if err := c.Commit(offset); err != nil {
+			t.Fatalf("Commit(%d): %v", offset, err)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("directory has %d entries after 3 commits, want exactly 1 (the final commit file, no leftover temp files)", len(entries))
+	}
+}
@@ -0,0 +1,190 @@
+package _interface
+
+import (
+	"math"
+	"sync"
+)
+
+// ReceivedMessage pairs a delivered Message with the byte tokens it was
+// charged against the subscriber's flow-control budget, so a Stream
+// consumer can replenish the correct amount when acknowledging it.
+type ReceivedMessage struct {
+	// Message is the delivered message.
+	Message *Message
+
+	// ByteSize is the number of bytes this message counts against the
+	// subscriber's outstanding byte-token budget. Acking the message
+	// replenishes the byte token bucket by this amount and the message
+	// token bucket by one.
+	ByteSize int64
+}
+
+// Stream is a flow-controlled delivery channel returned by
+// Subscriber.SubscribeStream. It tracks per-subscriber outstanding bytes
+// and message counts as two independent token buckets; delivery pauses
+// whenever either bucket reaches zero and resumes once AllowFlow tops
+// it back up. Acking a ReceivedMessage automatically replenishes the
+// byte bucket by the size of the acked message and the message bucket
+// by one.
+type Stream interface {
+	// AllowFlow grants additional byte and message tokens to the
+	// subscriber, resuming delivery if it was paused because a token
+	// bucket had reached zero.
+	AllowFlow(byteTokens, msgTokens int64)
+
+	// Messages returns the channel on which delivered messages arrive.
+	// It is closed once the stream is closed.
+	Messages() <-chan *ReceivedMessage
+
+	// Close stops delivery and releases the stream's resources.
+	Close() error
+}
+
+// SubscribeSettings configures outstanding-message flow control for a
+// streaming subscription, mirroring the receive settings exposed by
+// Cloud Pub/Sub client libraries.
+type SubscribeSettings struct {
+	// MaxOutstandingMessages is the maximum number of delivered but
+	// unacknowledged messages before delivery pauses. Values <= 0 mean
+	// no limit.
+	MaxOutstandingMessages int64
+
+	// MaxOutstandingBytes is the maximum total size, in bytes, of
+	// delivered but unacknowledged messages before delivery pauses.
+	// Values <= 0 mean no limit.
+	MaxOutstandingBytes int64
+}
+
+// DefaultSubscribeSettings mirrors the Cloud Pub/Sub client library
+// defaults and is used whenever SubscriptionOptions.SubscribeSettings
+// is nil.
+var DefaultSubscribeSettings = SubscribeSettings{
+	MaxOutstandingMessages: 1000,
+	MaxOutstandingBytes:    1000 * 1000 * 1000,
+}
+
+// queuedMessage is a message waiting in a TokenStream's delivery queue
+// for enough tokens to free up.
+type queuedMessage struct {
+	msg  *Message
+	size int64
+}
+
+// TokenStream is the Stream implementation returned by NewTokenStream.
+// It holds outstanding bytes and message counts as two independent
+// token buckets, seeded from a SubscribeSettings, and runs a single
+// dispatch loop that blocks until both buckets are positive before
+// sending the next queued message on its Messages channel.
+type TokenStream struct {
+	ch   chan *ReceivedMessage
+	done chan struct{}
+
+	mu         sync.Mutex
+	cond       *sync.Cond
+	byteTokens int64
+	msgTokens  int64
+	queue      []queuedMessage
+	closed     bool
+}
+
+// NewTokenStream creates a Stream backed by token-bucket counters
+// seeded from settings. It is a standalone building block a broker can
+// feed via Deliver to back Subscriber.SubscribeStream.
+func NewTokenStream(settings SubscribeSettings) *TokenStream {
+	s := &TokenStream{
+		ch:         make(chan *ReceivedMessage),
+		done:       make(chan struct{}),
+		byteTokens: settings.MaxOutstandingBytes,
+		msgTokens:  settings.MaxOutstandingMessages,
+	}
+	// A non-positive limit means no limit: seed that bucket high enough
+	// that it never runs dry.
+	if s.byteTokens <= 0 {
+		s.byteTokens = math.MaxInt64 / 2
+	}
+	if s.msgTokens <= 0 {
+		s.msgTokens = math.MaxInt64 / 2
+	}
+	s.cond = sync.NewCond(&s.mu)
+	go s.run()
+	return s
+}
+
+// Deliver enqueues msg for delivery, charging it size bytes against the
+// stream's outstanding-bytes budget. It returns immediately; the
+// message is sent on Messages() once both token buckets allow it.
+func (s *TokenStream) Deliver(msg *Message, size int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.queue = append(s.queue, queuedMessage{msg: msg, size: size})
+	s.cond.Broadcast()
+}
+
+// Ack replenishes the token buckets for an acked message's size,
+// resuming delivery if it was paused. Brokers should call this from
+// their ack-processing path for every message delivered on this stream.
+func (s *TokenStream) Ack(size int64) {
+	s.AllowFlow(size, 1)
+}
+
+// AllowFlow implements Stream.
+func (s *TokenStream) AllowFlow(byteTokens, msgTokens int64) {
+	s.mu.Lock()
+	s.byteTokens += byteTokens
+	s.msgTokens += msgTokens
+	s.cond.Broadcast()
+	s.mu.Unlock()
+}
+
+// Messages implements Stream.
+func (s *TokenStream) Messages() <-chan *ReceivedMessage {
+	return s.ch
+}
+
+// Close implements Stream. It returns promptly even if run is blocked
+// sending a message to a consumer that has stopped reading Messages().
+func (s *TokenStream) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.cond.Broadcast()
+	s.mu.Unlock()
+	close(s.done)
+	return nil
+}
+
+// run is the stream's dispatch loop: it waits until a message is queued
+// and both token buckets are positive, then sends the oldest queued
+// message on ch, charging it against the buckets. The send races
+// against done so Close can abort a delivery nobody is reading.
+func (s *TokenStream) run() {
+	for {
+		s.mu.Lock()
+		for !s.closed && (len(s.queue) == 0 || s.byteTokens <= 0 || s.msgTokens <= 0) {
+			s.cond.Wait()
+		}
+		if s.closed {
+			s.mu.Unlock()
+			close(s.ch)
+			return
+		}
+		next := s.queue[0]
+		s.queue = s.queue[1:]
+		s.byteTokens -= next.size
+		s.msgTokens--
+		s.mu.Unlock()
+
+		select {
+		case s.ch <- &ReceivedMessage{Message: next.msg, ByteSize: next.size}:
+		case <-s.done:
+			close(s.ch)
+			return
+		}
+	}
+}
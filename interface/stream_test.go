@@ -0,0 +1,86 @@
+package _interface
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenStreamPausesWhenTokensExhausted(t *testing.T) {
+	s := NewTokenStream(SubscribeSettings{MaxOutstandingMessages: 1, MaxOutstandingBytes: 100})
+	defer s.Close()
+
+	s.Deliver(&Message{MessageID: "1"}, 10)
+	s.Deliver(&Message{MessageID: "2"}, 10)
+
+	first := <-s.Messages()
+	if first.Message.MessageID != "1" {
+		t.Fatalf("first = %s, want 1", first.Message.MessageID)
+	}
+
+	select {
+	case <-s.Messages():
+		t.Fatal("second message delivered before tokens were replenished")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	s.AllowFlow(10, 1)
+	select {
+	case second := <-s.Messages():
+		if second.Message.MessageID != "2" {
+			t.Fatalf("second = %s, want 2", second.Message.MessageID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("second message never delivered after AllowFlow")
+	}
+}
+
+func TestTokenStreamAckReplenishesTokens(t *testing.T) {
+	s := NewTokenStream(SubscribeSettings{MaxOutstandingMessages: 1, MaxOutstandingBytes: 100})
+	defer s.Close()
+
+	s.Deliver(&Message{MessageID: "1"}, 10)
+	s.Deliver(&Message{MessageID: "2"}, 10)
+
+	first := <-s.Messages()
+	s.Ack(first.ByteSize)
+
+	select {
+	case second := <-s.Messages():
+		if second.Message.MessageID != "2" {
+			t.Fatalf("second = %s, want 2", second.Message.MessageID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("second message never delivered after Ack")
+	}
+}
+
+func TestTokenStreamCloseUnblocksPendingDeliveryAndClosesChannel(t *testing.T) {
+	s := NewTokenStream(SubscribeSettings{MaxOutstandingMessages: 5, MaxOutstandingBytes: 1000})
+	s.Deliver(&Message{MessageID: "1"}, 10)
+
+	// Give run() a chance to pop the message and block on the unbuffered
+	// send with no reader, reproducing the scenario Close must recover
+	// from.
+	time.Sleep(50 * time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		s.Close()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Close did not return; run() is stuck on a blocked send")
+	}
+
+	select {
+	case _, ok := <-s.Messages():
+		if ok {
+			t.Fatal("expected Messages() to be closed, got a value instead")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Messages() channel was never closed after Close")
+	}
+}